@@ -0,0 +1,59 @@
+package goappfsx
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAtomicWriteFileExeDirRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	fs := WithBackend(OSBackend{})
+
+	// Use a MemBackend-free, real-filesystem round trip via OpenFileExeDirWithFlags
+	// is awkward since ExeDir() is fixed to the test binary's location; exercise
+	// atomicWriteFile directly instead, the way AtomicWriteFileExeDir does.
+	n, err := atomicWriteFile(fs.backend, dir, "f.txt", []byte("durable"), nil)
+	if err != nil {
+		t.Fatalf("atomicWriteFile: %v", err)
+	}
+	if n != len("durable") {
+		t.Fatalf("atomicWriteFile: wrote %d bytes, want %d", n, len("durable"))
+	}
+
+	got, err := os.ReadFile(filepath.Join(dir, "f.txt"))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if string(got) != "durable" {
+		t.Fatalf("got %q, want %q", got, "durable")
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("ReadDir: got %d entries, want 1 (no leftover temp file)", len(entries))
+	}
+}
+
+func TestAtomicWriteFileOverwritesExisting(t *testing.T) {
+	dir := t.TempDir()
+	fs := WithBackend(OSBackend{})
+
+	if _, err := atomicWriteFile(fs.backend, dir, "f.txt", []byte("first"), nil); err != nil {
+		t.Fatalf("atomicWriteFile (first): %v", err)
+	}
+	if _, err := atomicWriteFile(fs.backend, dir, "f.txt", []byte("second"), nil); err != nil {
+		t.Fatalf("atomicWriteFile (second): %v", err)
+	}
+
+	got, err := os.ReadFile(filepath.Join(dir, "f.txt"))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if string(got) != "second" {
+		t.Fatalf("got %q, want %q", got, "second")
+	}
+}
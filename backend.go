@@ -0,0 +1,107 @@
+package goappfsx
+
+import (
+	"io"
+	"io/fs"
+	"os"
+)
+
+// File is the behavior required of a file handle returned by a Backend.  It
+// is satisfied by `*os.File` as returned by OSBackend, and by the handles
+// returned by MemBackend for use in tests.
+type File interface {
+	io.Reader
+	io.Writer
+	io.Closer
+	Stat() (fs.FileInfo, error)
+	Name() string
+	Sync() error
+}
+
+// Backend abstracts the filesystem operations that goappfsx needs in order to
+// resolve and manipulate files under the executable and application data
+// directories.  The default Backend is OSBackend, which forwards to the `os`
+// package, but callers may supply their own (an in-memory backend for tests,
+// an S3-backed store, an encrypted overlay, etc.) via SetBackend or
+// WithBackend.
+type Backend interface {
+	Open(name string) (File, error)
+	Create(name string) (File, error)
+	OpenFile(name string, flag int, perm os.FileMode) (File, error)
+	MkdirAll(path string, perm os.FileMode) error
+	Stat(name string) (fs.FileInfo, error)
+	Remove(name string) error
+	Rename(oldpath, newpath string) error
+	ReadDir(name string) ([]fs.DirEntry, error)
+}
+
+// OSBackend is the default Backend.  Every method forwards directly to the
+// equivalent function in the `os` package.
+type OSBackend struct{}
+
+// Open forwards to `os.Open`.
+func (OSBackend) Open(name string) (File, error) {
+	return os.Open(name)
+}
+
+// Create forwards to `os.Create`.
+func (OSBackend) Create(name string) (File, error) {
+	return os.Create(name)
+}
+
+// OpenFile forwards to `os.OpenFile`.
+func (OSBackend) OpenFile(name string, flag int, perm os.FileMode) (File, error) {
+	return os.OpenFile(name, flag, perm)
+}
+
+// MkdirAll forwards to `os.MkdirAll`.
+func (OSBackend) MkdirAll(path string, perm os.FileMode) error {
+	return os.MkdirAll(path, perm)
+}
+
+// Stat forwards to `os.Stat`.
+func (OSBackend) Stat(name string) (fs.FileInfo, error) {
+	return os.Stat(name)
+}
+
+// Remove forwards to `os.Remove`.
+func (OSBackend) Remove(name string) error {
+	return os.Remove(name)
+}
+
+// Rename forwards to `os.Rename`.
+func (OSBackend) Rename(oldpath, newpath string) error {
+	return os.Rename(oldpath, newpath)
+}
+
+// ReadDir forwards to `os.ReadDir`.
+func (OSBackend) ReadDir(name string) ([]fs.DirEntry, error) {
+	return os.ReadDir(name)
+}
+
+// FS bundles a Backend with the ExeDir/AppDataDir helpers so that a caller
+// can use a non-default Backend without affecting the package-level
+// functions.  The zero value is not usable; construct one with WithBackend.
+type FS struct {
+	backend Backend
+}
+
+// WithBackend returns an FS that routes every helper method through b
+// instead of the package-level default.  Use this when only part of a
+// program should talk to a non-default Backend (an S3 overlay for one
+// subsystem, a MemBackend in one test); use SetBackend to change the
+// default for every caller instead.
+func WithBackend(b Backend) *FS {
+	return &FS{backend: b}
+}
+
+// defaultFS is used by the package-level functions (ExeDir, ReadFileExeDir,
+// and so on).  SetBackend replaces its Backend.
+var defaultFS = WithBackend(OSBackend{})
+
+// SetBackend replaces the Backend used by every package-level helper
+// (ReadFileExeDir, WriteFileAppDataDir, ...).  It is not safe to call
+// concurrently with those helpers.
+func SetBackend(b Backend) {
+	defaultFS.backend = b
+}
@@ -0,0 +1,85 @@
+package goappfsx
+
+import (
+	"compress/gzip"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Compressor adapts an underlying reader or writer to add (or remove) a
+// compression layer.  Store uses it to transparently compress values on
+// write and decompress them on read.
+//
+// NopCompression and GzipCompression wrap the standard library; ZstdCompression
+// wraps github.com/klauspost/compress/zstd for callers that want a better
+// ratio/speed tradeoff than gzip.
+type Compressor interface {
+	NewReader(r io.Reader) (io.ReadCloser, error)
+	NewWriter(w io.Writer) (io.WriteCloser, error)
+}
+
+// NopCompression is a Compressor that performs no compression at all.  It is
+// the default used by NewStore.
+type NopCompression struct{}
+
+// NewReader returns r wrapped only enough to satisfy io.ReadCloser.
+func (NopCompression) NewReader(r io.Reader) (io.ReadCloser, error) {
+	return io.NopCloser(r), nil
+}
+
+// NewWriter returns w wrapped only enough to satisfy io.WriteCloser.
+func (NopCompression) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	return nopWriteCloser{w}, nil
+}
+
+type nopWriteCloser struct{ io.Writer }
+
+func (nopWriteCloser) Close() error { return nil }
+
+// GzipCompression is a Compressor that compresses values with gzip.
+type GzipCompression struct{}
+
+// NewReader returns a gzip reader over r.
+func (GzipCompression) NewReader(r io.Reader) (io.ReadCloser, error) {
+	return gzip.NewReader(r)
+}
+
+// NewWriter returns a gzip writer over w.  Callers must Close the writer to
+// flush the final gzip block.
+func (GzipCompression) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	return gzip.NewWriter(w), nil
+}
+
+// ZstdCompression is a Compressor that compresses values with zstd.
+type ZstdCompression struct{}
+
+// NewReader returns a zstd reader over r.  The returned ReadCloser's Close
+// never returns an error; it matches the zstd.Decoder's own Close, which is
+// infallible.
+func (ZstdCompression) NewReader(r io.Reader) (io.ReadCloser, error) {
+	dec, err := zstd.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	return zstdDecoderCloser{dec}, nil
+}
+
+// NewWriter returns a zstd writer over w.  Callers must Close the writer to
+// flush the final zstd frame.
+func (ZstdCompression) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	return zstd.NewWriter(w)
+}
+
+// zstdDecoderCloser adapts *zstd.Decoder's Close (which returns nothing) to
+// io.Closer.
+type zstdDecoderCloser struct{ dec *zstd.Decoder }
+
+func (d zstdDecoderCloser) Read(p []byte) (int, error) {
+	return d.dec.Read(p)
+}
+
+func (d zstdDecoderCloser) Close() error {
+	d.dec.Close()
+	return nil
+}
@@ -3,9 +3,9 @@
 package goappfsx
 
 import (
-	"io/ioutil"
+	"io"
 	"os"
-	"path"
+	"path/filepath"
 )
 
 // DataCategory is an enumeration of the categories used in Windows to dileniate
@@ -34,50 +34,51 @@ func ExeDir() (string, error) {
 	if err != nil {
 		return "", err
 	}
-	return path.Dir(exePath), nil
+	return filepath.Dir(exePath), nil
 }
 
-// OpenFileExeDir returns a reference to an `os.File` given a path supplement to
-// the executable directory.  Any errors encountered are passed directly to the
-// caller.
-func OpenFileExeDir(pathSupplement string) (*os.File, error) {
-	ed, err := ExeDir()
-	if err != nil {
-		return nil, err
-	}
-	fp := path.Join(ed, pathSupplement)
-	fyle, err := os.Open(fp)
-	if err != nil {
-		return nil, err
-	}
-	return fyle, err
+// OpenFileExeDir returns a reference to a File given a path supplement to
+// the executable directory, opened read-only.  Any errors encountered are
+// passed directly to the caller.  It is a thin wrapper around
+// OpenFileExeDirWithFlags kept for source compatibility; use
+// OpenFileExeDirWithFlags for write, append, or permission control.
+func (fs *FS) OpenFileExeDir(pathSupplement string) (File, error) {
+	return fs.OpenFileExeDirWithFlags(pathSupplement, os.O_RDONLY, 0)
+}
+
+// OpenFileExeDir is the package-level equivalent of (*FS).OpenFileExeDir,
+// using the default Backend.
+func OpenFileExeDir(pathSupplement string) (File, error) {
+	return defaultFS.OpenFileExeDir(pathSupplement)
 }
 
 // ReadFileExeDir returns a `[]byte` that is the result of reading the entire
 // contents of the requested file.  `pathSupplement` is the portion of the path
 // relative to the executable directory.
-func ReadFileExeDir(pathSupplement string) ([]byte, error) {
+func (fs *FS) ReadFileExeDir(pathSupplement string) ([]byte, error) {
 	ed, err := ExeDir()
 	if err != nil {
 		return nil, err
 	}
-	out, err := ioutil.ReadFile(path.Join(ed, pathSupplement))
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+	return readAllUnder(fs.backend, ed, pathSupplement)
+}
+
+// ReadFileExeDir is the package-level equivalent of (*FS).ReadFileExeDir,
+// using the default Backend.
+func ReadFileExeDir(pathSupplement string) ([]byte, error) {
+	return defaultFS.ReadFileExeDir(pathSupplement)
 }
 
 // WriteFileExeDir writes a `[]byte` to the path provided in pathSupplement.  If
 // the file does not exist, it creates it.  If the file does exist, it truncates
-// it first.  Returns the number of `byte`s written.  Wraps the `os.File`
-// methods and passes any errors encounted directly to the caller.
-func WriteFileExeDir(pathSupplement string, data []byte) (int, error) {
+// it first.  Returns the number of `byte`s written.  Wraps the Backend's file
+// handle and passes any errors encounted directly to the caller.
+func (fs *FS) WriteFileExeDir(pathSupplement string, data []byte) (int, error) {
 	ed, err := ExeDir()
 	if err != nil {
 		return 0, err
 	}
-	fyle, err := os.Create(path.Join(ed, pathSupplement))
+	fyle, err := openUnder(fs.backend, ed, pathSupplement, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o666)
 	if err != nil {
 		return 0, err
 	}
@@ -85,41 +86,52 @@ func WriteFileExeDir(pathSupplement string, data []byte) (int, error) {
 	return fyle.Write(data)
 }
 
+// WriteFileExeDir is the package-level equivalent of (*FS).WriteFileExeDir,
+// using the default Backend.
+func WriteFileExeDir(pathSupplement string, data []byte) (int, error) {
+	return defaultFS.WriteFileExeDir(pathSupplement, data)
+}
+
 // AppDataDir returns the application directory within the current user's
 // configuration directory.  It wraps `os.UserConfigDir` and passes any errors
 // encountered directly to the caller.  If the directory doesn't yet exist, it
 // creates it prior to returning.
-func AppDataDir(category DataCategory) (string, error) {
-	progName := path.Base(os.Args[0])
-	progExt := path.Ext(progName)
+func (fs *FS) AppDataDir(category DataCategory) (string, error) {
+	progName := filepath.Base(os.Args[0])
+	progExt := filepath.Ext(progName)
 	progName = progName[:len(progName)-len(progExt)]
 
 	appDir, err := os.UserConfigDir()
 	if err != nil {
 		return "", err
 	}
-	appDir = path.Join(appDir, category.String(), progName)
-	os.MkdirAll(appDir, os.ModeDir)
+	appDir = filepath.Join(appDir, category.String(), progName)
+	fs.backend.MkdirAll(appDir, 0o755)
 	return appDir, nil
 }
 
-// OpenFileAppDataDir returns a reference to an `os.File` given a path
-// supplement to the User's configuration directory.  It wraps
-// `os.UserConfigDir` and passes any errors encountered directly to the caller.
-// If the application specific configuration directory doesn't yet exist, it
-// creates it in the process.  It does *not* create the file if that doesn't
-// exist.
-func OpenFileAppDataDir(pathSupplement string, category DataCategory) (*os.File, error) {
-	add, err := AppDataDir(category)
-	if err != nil {
-		return nil, err
-	}
-	fp := path.Join(add, pathSupplement)
-	fyle, err := os.Open(fp)
-	if err != nil {
-		return nil, err
-	}
-	return fyle, err
+// AppDataDir is the package-level equivalent of (*FS).AppDataDir, using the
+// default Backend.
+func AppDataDir(category DataCategory) (string, error) {
+	return defaultFS.AppDataDir(category)
+}
+
+// OpenFileAppDataDir returns a reference to a File given a path
+// supplement to the User's configuration directory, opened read-only.  It
+// wraps `os.UserConfigDir` and passes any errors encountered directly to the
+// caller.  If the application specific configuration directory doesn't yet
+// exist, it creates it in the process.  It does *not* create the file if
+// that doesn't exist.  It is a thin wrapper around
+// OpenFileAppDataDirWithFlags kept for source compatibility; use
+// OpenFileAppDataDirWithFlags for write, append, or permission control.
+func (fs *FS) OpenFileAppDataDir(pathSupplement string, category DataCategory) (File, error) {
+	return fs.OpenFileAppDataDirWithFlags(pathSupplement, category, os.O_RDONLY, 0)
+}
+
+// OpenFileAppDataDir is the package-level equivalent of
+// (*FS).OpenFileAppDataDir, using the default Backend.
+func OpenFileAppDataDir(pathSupplement string, category DataCategory) (File, error) {
+	return defaultFS.OpenFileAppDataDir(pathSupplement, category)
 }
 
 // ReadFileAppDataDir returns a `[]byte` that is the result of reading the
@@ -128,31 +140,51 @@ func OpenFileAppDataDir(pathSupplement string, category DataCategory) (*os.File,
 // configuration directory.  If the application specific configuration directory
 // doesn't yet exist, it creates it in the process.  It does *not* create the
 // file if that doesn't exist.
-func ReadFileAppDataDir(pathSupplement string, category DataCategory) ([]byte, error) {
-	add, err := AppDataDir(category)
-	if err != nil {
-		return nil, err
-	}
-	out, err := ioutil.ReadFile(path.Join(add, pathSupplement))
+func (fs *FS) ReadFileAppDataDir(pathSupplement string, category DataCategory) ([]byte, error) {
+	add, err := fs.AppDataDir(category)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	return readAllUnder(fs.backend, add, pathSupplement)
+}
+
+// ReadFileAppDataDir is the package-level equivalent of
+// (*FS).ReadFileAppDataDir, using the default Backend.
+func ReadFileAppDataDir(pathSupplement string, category DataCategory) ([]byte, error) {
+	return defaultFS.ReadFileAppDataDir(pathSupplement, category)
 }
 
 // WriteFileAppDataDir writes a `[]byte` to the path provided in pathSupplement.
 // If the file does not exist, it creates it.  If the file does exist, it
 // truncates it first.  Returns the number of `byte`s written.  Wraps the
-// `os.File` methods and passes any errors encounted directly to the caller.
-func WriteFileAppDataDir(pathSupplement string, category DataCategory, data []byte) (int, error) {
-	add, err := AppDataDir(category)
+// Backend's file handle and passes any errors encounted directly to the
+// caller.
+func (fs *FS) WriteFileAppDataDir(pathSupplement string, category DataCategory, data []byte) (int, error) {
+	add, err := fs.AppDataDir(category)
 	if err != nil {
 		return 0, err
 	}
-	fyle, err := os.Create(path.Join(add, pathSupplement))
+	fyle, err := openUnder(fs.backend, add, pathSupplement, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o666)
 	if err != nil {
 		return 0, err
 	}
 	defer fyle.Close()
 	return fyle.Write(data)
 }
+
+// WriteFileAppDataDir is the package-level equivalent of
+// (*FS).WriteFileAppDataDir, using the default Backend.
+func WriteFileAppDataDir(pathSupplement string, category DataCategory, data []byte) (int, error) {
+	return defaultFS.WriteFileAppDataDir(pathSupplement, category, data)
+}
+
+// readAllUnder opens pathSupplement beneath base via openUnder and reads it
+// in full.
+func readAllUnder(backend Backend, base, pathSupplement string) ([]byte, error) {
+	fyle, err := openUnder(backend, base, pathSupplement, os.O_RDONLY, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer fyle.Close()
+	return io.ReadAll(fyle)
+}
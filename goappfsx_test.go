@@ -0,0 +1,77 @@
+package goappfsx
+
+import (
+	"testing"
+)
+
+func TestAppDataDirCreatesWithUsableMode(t *testing.T) {
+	var m MemBackend
+	fs := WithBackend(&m)
+
+	dir, err := fs.AppDataDir(None)
+	if err != nil {
+		t.Fatalf("AppDataDir: %v", err)
+	}
+
+	info, err := m.Stat(dir)
+	if err != nil {
+		t.Fatalf("Stat: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm == 0 {
+		t.Fatalf("AppDataDir directory mode is %v, want a usable (non-zero) permission", perm)
+	}
+}
+
+func TestWriteReadFileExeDirRoundTrip(t *testing.T) {
+	var m MemBackend
+	fs := WithBackend(&m)
+
+	ed, err := ExeDir()
+	if err != nil {
+		t.Fatalf("ExeDir: %v", err)
+	}
+	if err := m.MkdirAll(ed, 0o755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+
+	if _, err := fs.WriteFileExeDir("f.txt", []byte("hello")); err != nil {
+		t.Fatalf("WriteFileExeDir: %v", err)
+	}
+	got, err := fs.ReadFileExeDir("f.txt")
+	if err != nil {
+		t.Fatalf("ReadFileExeDir: %v", err)
+	}
+	if string(got) != "hello" {
+		t.Fatalf("got %q, want %q", got, "hello")
+	}
+}
+
+func TestWriteReadFileAppDataDirRoundTrip(t *testing.T) {
+	var m MemBackend
+	fs := WithBackend(&m)
+
+	if _, err := fs.WriteFileAppDataDir("f.txt", Local, []byte("world")); err != nil {
+		t.Fatalf("WriteFileAppDataDir: %v", err)
+	}
+	got, err := fs.ReadFileAppDataDir("f.txt", Local)
+	if err != nil {
+		t.Fatalf("ReadFileAppDataDir: %v", err)
+	}
+	if string(got) != "world" {
+		t.Fatalf("got %q, want %q", got, "world")
+	}
+}
+
+func TestDataCategoryString(t *testing.T) {
+	cases := map[DataCategory]string{
+		None:     "",
+		Local:    "Local",
+		LocalLow: "LocalLow",
+		Roaming:  "Roaming",
+	}
+	for dc, want := range cases {
+		if got := dc.String(); got != want {
+			t.Errorf("%v.String(): got %q, want %q", dc, got, want)
+		}
+	}
+}
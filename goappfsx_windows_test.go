@@ -0,0 +1,34 @@
+//go:build windows
+
+package goappfsx
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestAppDataDirRoamingUsesBackslashSeparators guards the Windows-specific
+// behavior chunk0-6 migrated onto filepath for: AppDataDir(Roaming) must
+// yield a path under %APPDATA%\Roaming\<progName> joined with backslashes,
+// not the forward slashes `path.Join` used to produce.
+func TestAppDataDirRoamingUsesBackslashSeparators(t *testing.T) {
+	appData, err := os.UserConfigDir()
+	if err != nil {
+		t.Fatalf("os.UserConfigDir: %v", err)
+	}
+
+	dir, err := AppDataDir(Roaming)
+	if err != nil {
+		t.Fatalf("AppDataDir: %v", err)
+	}
+
+	want := filepath.Join(appData, Roaming.String())
+	if !strings.HasPrefix(dir, want+string(filepath.Separator)) {
+		t.Fatalf("AppDataDir(Roaming) = %q, want a path under %q", dir, want)
+	}
+	if strings.Contains(dir, "/") {
+		t.Fatalf("AppDataDir(Roaming) = %q, want backslash separators only", dir)
+	}
+}
@@ -0,0 +1,247 @@
+package goappfsx
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// MemBackend is an in-memory Backend.  It exists primarily so that code
+// built on top of goappfsx (and goappfsx's own tests) can exercise
+// ExeDir/AppDataDir-based logic without touching real disk.  The zero value
+// is a ready-to-use, empty filesystem.
+type MemBackend struct {
+	mu      sync.Mutex
+	entries map[string]*memEntry
+}
+
+type memEntry struct {
+	dir     bool
+	data    []byte
+	modTime time.Time
+}
+
+func (m *MemBackend) init() {
+	if m.entries == nil {
+		m.entries = make(map[string]*memEntry)
+	}
+}
+
+func memClean(name string) string {
+	return filepath.ToSlash(filepath.Clean(name))
+}
+
+// Open opens name for reading.  It returns `fs.ErrNotExist` if name does not
+// exist or is a directory.
+func (m *MemBackend) Open(name string) (File, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.init()
+	name = memClean(name)
+	ent, ok := m.entries[name]
+	if !ok || ent.dir {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return &memFile{backend: m, name: name, reader: bytes.NewReader(ent.data)}, nil
+}
+
+// Create creates (or truncates) name for writing.
+func (m *MemBackend) Create(name string) (File, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.init()
+	name = memClean(name)
+	m.entries[name] = &memEntry{modTime: time.Now()}
+	return &memFile{backend: m, name: name, writable: true}, nil
+}
+
+// OpenFile opens name the way `os.OpenFile` does: flag controls
+// read/write/append/create/truncate behavior.  Unlike a real file, a
+// non-append writable handle always starts from an empty buffer (there is
+// no in-place, offset-based overwrite), so O_RDWR without O_APPEND behaves
+// like O_TRUNC for writes; this is a simplification MemBackend makes since
+// it exists for tests, not as a byte-for-byte os.File substitute.
+func (m *MemBackend) OpenFile(name string, flag int, perm os.FileMode) (File, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.init()
+	name = memClean(name)
+
+	ent, ok := m.entries[name]
+	switch {
+	case !ok && flag&os.O_CREATE != 0:
+		ent = &memEntry{modTime: time.Now()}
+		m.entries[name] = ent
+	case !ok:
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	case ent.dir:
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+
+	if flag&os.O_TRUNC != 0 {
+		ent.data = nil
+	}
+
+	f := &memFile{backend: m, name: name}
+	if flag&(os.O_WRONLY|os.O_RDWR) != 0 {
+		f.writable = true
+		if flag&os.O_APPEND != 0 {
+			f.buf.Write(ent.data)
+		}
+	}
+	if flag&os.O_WRONLY == 0 {
+		f.reader = bytes.NewReader(ent.data)
+	}
+	return f, nil
+}
+
+// MkdirAll creates path and any missing parents as directories.
+func (m *MemBackend) MkdirAll(path string, perm os.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.init()
+	path = memClean(path)
+	for dir := path; dir != "." && dir != "/" && dir != ""; dir = filepath.ToSlash(filepath.Dir(dir)) {
+		if ent, ok := m.entries[dir]; ok {
+			if !ent.dir {
+				return &fs.PathError{Op: "mkdir", Path: dir, Err: fs.ErrExist}
+			}
+			continue
+		}
+		m.entries[dir] = &memEntry{dir: true, modTime: time.Now()}
+	}
+	return nil
+}
+
+// Stat returns file info for name.
+func (m *MemBackend) Stat(name string) (fs.FileInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.init()
+	name = memClean(name)
+	ent, ok := m.entries[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+	}
+	return memFileInfo{name: filepath.Base(name), entry: ent}, nil
+}
+
+// Remove removes name.
+func (m *MemBackend) Remove(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.init()
+	name = memClean(name)
+	if _, ok := m.entries[name]; !ok {
+		return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrNotExist}
+	}
+	delete(m.entries, name)
+	return nil
+}
+
+// Rename moves oldpath to newpath, overwriting newpath if it already exists.
+func (m *MemBackend) Rename(oldpath, newpath string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.init()
+	oldpath, newpath = memClean(oldpath), memClean(newpath)
+	ent, ok := m.entries[oldpath]
+	if !ok {
+		return &fs.PathError{Op: "rename", Path: oldpath, Err: fs.ErrNotExist}
+	}
+	delete(m.entries, oldpath)
+	m.entries[newpath] = ent
+	return nil
+}
+
+// ReadDir lists the immediate children of name.
+func (m *MemBackend) ReadDir(name string) ([]fs.DirEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.init()
+	name = memClean(name)
+	if ent, ok := m.entries[name]; !ok || !ent.dir {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+	var out []fs.DirEntry
+	for path, ent := range m.entries {
+		if filepath.ToSlash(filepath.Dir(path)) == name && path != name {
+			out = append(out, fs.FileInfoToDirEntry(memFileInfo{name: filepath.Base(path), entry: ent}))
+		}
+	}
+	return out, nil
+}
+
+// memFile is the File implementation returned by MemBackend.
+type memFile struct {
+	backend  *MemBackend
+	name     string
+	writable bool
+	reader   *bytes.Reader
+	buf      bytes.Buffer
+}
+
+func (f *memFile) Read(p []byte) (int, error) {
+	if f.reader == nil {
+		return 0, &fs.PathError{Op: "read", Path: f.name, Err: fs.ErrInvalid}
+	}
+	return f.reader.Read(p)
+}
+
+func (f *memFile) Write(p []byte) (int, error) {
+	if !f.writable {
+		return 0, &fs.PathError{Op: "write", Path: f.name, Err: fs.ErrInvalid}
+	}
+	n, err := f.buf.Write(p)
+	f.backend.mu.Lock()
+	if ent, ok := f.backend.entries[f.name]; ok {
+		ent.data = f.buf.Bytes()
+		ent.modTime = time.Now()
+	}
+	f.backend.mu.Unlock()
+	return n, err
+}
+
+func (f *memFile) Close() error {
+	return nil
+}
+
+// Sync is a no-op: a MemBackend has nothing durable to flush.
+func (f *memFile) Sync() error {
+	return nil
+}
+
+func (f *memFile) Stat() (fs.FileInfo, error) {
+	f.backend.mu.Lock()
+	defer f.backend.mu.Unlock()
+	ent, ok := f.backend.entries[f.name]
+	if !ok {
+		return nil, &fs.PathError{Op: "stat", Path: f.name, Err: fs.ErrNotExist}
+	}
+	return memFileInfo{name: filepath.Base(f.name), entry: ent}, nil
+}
+
+func (f *memFile) Name() string {
+	return f.name
+}
+
+// memFileInfo is the fs.FileInfo implementation backing MemBackend.
+type memFileInfo struct {
+	name  string
+	entry *memEntry
+}
+
+func (i memFileInfo) Name() string { return i.name }
+func (i memFileInfo) Size() int64  { return int64(len(i.entry.data)) }
+func (i memFileInfo) Mode() fs.FileMode {
+	if i.entry.dir {
+		return os.ModeDir | 0o755
+	}
+	return 0o644
+}
+func (i memFileInfo) ModTime() time.Time { return i.entry.modTime }
+func (i memFileInfo) IsDir() bool        { return i.entry.dir }
+func (i memFileInfo) Sys() interface{}   { return nil }
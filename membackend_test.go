@@ -0,0 +1,81 @@
+package goappfsx
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"testing"
+)
+
+func TestMemBackendOpenFileCreate(t *testing.T) {
+	var m MemBackend
+
+	if _, err := m.OpenFile("/a/b.txt", 0, 0); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("OpenFile on missing file: got err %v, want fs.ErrNotExist", err)
+	}
+
+	f, err := m.OpenFile("/a/b.txt", os.O_WRONLY|os.O_CREATE, 0o644)
+	if err != nil {
+		t.Fatalf("OpenFile with O_CREATE: %v", err)
+	}
+	if _, err := f.Write([]byte("hello")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	f.Close()
+
+	f, err = m.Open("/a/b.txt")
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer f.Close()
+	got, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	if string(got) != "hello" {
+		t.Fatalf("got %q, want %q", got, "hello")
+	}
+}
+
+func TestMemBackendMkdirAllAndReadDir(t *testing.T) {
+	var m MemBackend
+
+	if err := m.MkdirAll("/a/b/c", 0o755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	if _, err := m.OpenFile("/a/b/c/f.txt", os.O_WRONLY|os.O_CREATE, 0o644); err != nil {
+		t.Fatalf("OpenFile: %v", err)
+	}
+
+	entries, err := m.ReadDir("/a/b/c")
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "f.txt" {
+		t.Fatalf("ReadDir: got %v, want [f.txt]", entries)
+	}
+}
+
+func TestMemBackendRenameAndRemove(t *testing.T) {
+	var m MemBackend
+
+	if _, err := m.OpenFile("/a.txt", os.O_WRONLY|os.O_CREATE, 0o644); err != nil {
+		t.Fatalf("OpenFile: %v", err)
+	}
+	if err := m.Rename("/a.txt", "/b.txt"); err != nil {
+		t.Fatalf("Rename: %v", err)
+	}
+	if _, err := m.Stat("/a.txt"); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("Stat old path: got err %v, want fs.ErrNotExist", err)
+	}
+	if _, err := m.Stat("/b.txt"); err != nil {
+		t.Fatalf("Stat new path: %v", err)
+	}
+	if err := m.Remove("/b.txt"); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if _, err := m.Stat("/b.txt"); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("Stat removed path: got err %v, want fs.ErrNotExist", err)
+	}
+}
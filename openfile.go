@@ -0,0 +1,40 @@
+package goappfsx
+
+import "os"
+
+// OpenFileExeDirWithFlags opens the file at pathSupplement, relative to the
+// executable directory, the way `os.OpenFile` does: flag controls
+// read/write/append/create/truncate behavior and perm is used if the file
+// is created.  Unlike OpenFileExeDir, which is read-only, this lets callers
+// append to a log file (O_APPEND|O_CREATE|O_WRONLY), open for read/write,
+// or control permissions on creation.
+func (fs *FS) OpenFileExeDirWithFlags(pathSupplement string, flag int, perm os.FileMode) (File, error) {
+	ed, err := ExeDir()
+	if err != nil {
+		return nil, err
+	}
+	return openUnder(fs.backend, ed, pathSupplement, flag, perm)
+}
+
+// OpenFileExeDirWithFlags is the package-level equivalent of
+// (*FS).OpenFileExeDirWithFlags, using the default Backend.
+func OpenFileExeDirWithFlags(pathSupplement string, flag int, perm os.FileMode) (File, error) {
+	return defaultFS.OpenFileExeDirWithFlags(pathSupplement, flag, perm)
+}
+
+// OpenFileAppDataDirWithFlags opens the file at pathSupplement, relative to
+// the application specific directory in category, the way
+// OpenFileExeDirWithFlags does relative to the executable directory.
+func (fs *FS) OpenFileAppDataDirWithFlags(pathSupplement string, category DataCategory, flag int, perm os.FileMode) (File, error) {
+	add, err := fs.AppDataDir(category)
+	if err != nil {
+		return nil, err
+	}
+	return openUnder(fs.backend, add, pathSupplement, flag, perm)
+}
+
+// OpenFileAppDataDirWithFlags is the package-level equivalent of
+// (*FS).OpenFileAppDataDirWithFlags, using the default Backend.
+func OpenFileAppDataDirWithFlags(pathSupplement string, category DataCategory, flag int, perm os.FileMode) (File, error) {
+	return defaultFS.OpenFileAppDataDirWithFlags(pathSupplement, category, flag, perm)
+}
@@ -0,0 +1,51 @@
+package goappfsx
+
+import (
+	"io"
+	"os"
+	"testing"
+)
+
+func TestOpenFileExeDirWithFlagsAppend(t *testing.T) {
+	var m MemBackend
+	fs := WithBackend(&m)
+
+	ed, err := ExeDir()
+	if err != nil {
+		t.Fatalf("ExeDir: %v", err)
+	}
+	if err := m.MkdirAll(ed, 0o755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+
+	f, err := fs.OpenFileExeDirWithFlags("log.txt", os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		t.Fatalf("OpenFileExeDirWithFlags (create): %v", err)
+	}
+	if _, err := f.Write([]byte("line1\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	f.Close()
+
+	f, err = fs.OpenFileExeDirWithFlags("log.txt", os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		t.Fatalf("OpenFileExeDirWithFlags (append): %v", err)
+	}
+	if _, err := f.Write([]byte("line2\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	f.Close()
+
+	f, err = fs.OpenFileExeDirWithFlags("log.txt", os.O_RDONLY, 0)
+	if err != nil {
+		t.Fatalf("OpenFileExeDirWithFlags (read): %v", err)
+	}
+	defer f.Close()
+	got, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	if want := "line1\nline2\n"; string(got) != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
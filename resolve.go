@@ -0,0 +1,113 @@
+package goappfsx
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+)
+
+// ErrPathEscapes is returned when a pathSupplement passed to one of the
+// *ExeDir/*AppDataDir functions would resolve outside of its base
+// directory (the executable directory, or the application specific
+// directory), for example via a `../../etc/passwd` supplement or a symlink
+// that leads out of the base.
+var ErrPathEscapes = errors.New("goappfsx: path escapes base directory")
+
+// OpenatMode selects how openUnder verifies that a resolved path stays
+// beneath its base directory before opening it.  resolveUnder always uses
+// the weaker, OpenatOpenat-equivalent containment check (see
+// platformResolveBeneath): it only hands back a string, so there is no open
+// to make atomic, and the TOCTOU protection below only matters for openUnder.
+type OpenatMode int32
+
+const (
+	// OpenatAuto probes the platform once and picks the strongest
+	// available mode.  It is the default.
+	OpenatAuto OpenatMode = iota
+	// OpenatOpenat falls back to a `path/filepath`-based containment
+	// check after the fact; it cannot defend against a TOCTOU race with a
+	// symlink swapped in mid-resolution.
+	OpenatOpenat
+	// OpenatOpenat2 makes openUnder resolve and open the path with
+	// `openat2(2)` and RESOLVE_BENEATH|RESOLVE_NO_SYMLINKS, which the
+	// kernel enforces atomically as one syscall.  This is stricter than a
+	// bare `path.Join` (or the OpenatOpenat/non-Linux fallback):
+	// RESOLVE_NO_SYMLINKS rejects *any* symlink component under base,
+	// including one that resolves to somewhere safely beneath base,
+	// failing the open with ErrPathEscapes (surfaced from the underlying
+	// ELOOP) rather than following it.  Linux only; on other platforms it
+	// behaves like OpenatOpenat.
+	OpenatOpenat2
+)
+
+// openatModeOverride is -1 when unset (meaning "probe automatically"),
+// otherwise holds a forced OpenatMode set via SetOpenatMode.
+var openatModeOverride int32 = -1
+
+// SetOpenatMode forces openUnder to use mode for every subsequent call,
+// bypassing the once-per-process capability probe.  Pass OpenatAuto to
+// restore automatic probing.  Intended for tests that need to exercise a
+// specific resolution strategy regardless of what the host OS supports.
+func SetOpenatMode(mode OpenatMode) {
+	if mode == OpenatAuto {
+		atomic.StoreInt32(&openatModeOverride, -1)
+		return
+	}
+	atomic.StoreInt32(&openatModeOverride, int32(mode))
+}
+
+// effectiveOpenatMode returns the forced mode if one was set via
+// SetOpenatMode, otherwise the result of the (cached) platform probe.
+func effectiveOpenatMode() OpenatMode {
+	if v := atomic.LoadInt32(&openatModeOverride); v >= 0 {
+		return OpenatMode(v)
+	}
+	return probeOpenatMode()
+}
+
+// resolveUnder joins base and supplement the way every *ExeDir/*AppDataDir
+// function used to with a bare `filepath.Join`, but rejects the result with
+// ErrPathEscapes if supplement resolves outside of base.  The check is
+// lexical (see containmentJoin): symlinks are not resolved, so a symlink
+// beneath base that leads out of it is not detected here.  See
+// platformResolveBeneath.
+//
+// resolveUnder only returns a validated string; callers that go on to open
+// the path themselves are exposed to a check-then-use race (the path could
+// be swapped out from under them between this call and their Open/Create)
+// and should use openUnder instead wherever the eventual operation is a
+// file open.
+func resolveUnder(base, supplement string) (string, error) {
+	return platformResolveBeneath(base, supplement)
+}
+
+// openUnder resolves supplement under base the same way resolveUnder does,
+// then opens it with flag/perm, combining validation and the real open so
+// that a platform resolver backed by something like openat2(2) can perform
+// both as one atomic kernel operation instead of leaving a race between
+// them.  See platformOpenBeneath.
+func openUnder(backend Backend, base, supplement string, flag int, perm os.FileMode) (File, error) {
+	return platformOpenBeneath(backend, base, supplement, flag, perm)
+}
+
+// containmentJoin is the fallback resolution strategy: join the paths, then
+// check after the fact (via `path/filepath.Rel`) that the result is still
+// under base.  Used directly on platforms with no openat2 equivalent, and
+// as the OpenatOpenat fallback on Linux.  It also returns rel, the path of
+// joined relative to base (as returned by filepath.Rel), since callers that
+// go on to open joined via a directory fd for base need a root-relative
+// path rather than an absolute one, and joined has already stripped any
+// "."/".." components that a naive TrimPrefix(joined, base) wouldn't.
+func containmentJoin(base, supplement string) (joined, rel string, err error) {
+	joined = filepath.Join(base, supplement)
+	rel, err = filepath.Rel(base, joined)
+	if err != nil {
+		return "", "", ErrPathEscapes
+	}
+	if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", "", ErrPathEscapes
+	}
+	return joined, rel, nil
+}
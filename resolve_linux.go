@@ -0,0 +1,110 @@
+//go:build linux
+
+package goappfsx
+
+import (
+	"io/fs"
+	"os"
+	"sync/atomic"
+
+	"golang.org/x/sys/unix"
+)
+
+// openat2Supported caches the result of probing for openat2(2) support:
+// -1 unknown (not yet probed), 0 unsupported, 1 supported.
+var openat2Supported int32 = -1
+
+// probeOpenatMode probes, once per process, whether the running kernel
+// supports openat2(2) (it landed in Linux 5.6) by issuing a harmless
+// Openat2 call against "/", following the pattern used to detect openat2
+// availability before relying on RESOLVE_BENEATH.  The result is cached in
+// openat2Supported via sync/atomic.
+func probeOpenatMode() OpenatMode {
+	if v := atomic.LoadInt32(&openat2Supported); v >= 0 {
+		if v == 1 {
+			return OpenatOpenat2
+		}
+		return OpenatOpenat
+	}
+
+	supported := int32(0)
+	if fd, err := unix.Openat2(unix.AT_FDCWD, "/", &unix.OpenHow{Flags: unix.O_RDONLY}); err == nil {
+		unix.Close(fd)
+		supported = 1
+	}
+	atomic.StoreInt32(&openat2Supported, supported)
+
+	if supported == 1 {
+		return OpenatOpenat2
+	}
+	return OpenatOpenat
+}
+
+// platformResolveBeneath verifies that supplement resolves to a path
+// beneath base.  It only hands back a validated string, so it is suitable
+// for callers that don't immediately open the result (Stat, Remove,
+// Rename, ReadDir, the directory argument to MkdirAll, ...); callers that
+// go on to open the path should use platformOpenBeneath instead, since a
+// plain string is inherently racy between this check and that open.
+func platformResolveBeneath(base, supplement string) (string, error) {
+	joined, _, err := containmentJoin(base, supplement)
+	return joined, err
+}
+
+// platformOpenBeneath verifies that supplement resolves to a path beneath
+// base and opens it with flag/perm as a single openat2(2) call using
+// RESOLVE_BENEATH|RESOLVE_NO_SYMLINKS, which the kernel enforces
+// atomically: there is no window between "verify" and "use" for a symlink
+// swapped in afterward to redirect the open, unlike resolving a string and
+// opening it separately. It falls back to platformResolveBeneath plus a
+// plain Backend.OpenFile when openat2 isn't available (older kernels, or
+// SetOpenatMode(OpenatOpenat)), or when backend isn't OSBackend (an
+// in-memory Backend has no fd-based symlink concept to race on).
+func platformOpenBeneath(backend Backend, base, supplement string, flag int, perm os.FileMode) (File, error) {
+	if _, ok := backend.(OSBackend); !ok || effectiveOpenatMode() != OpenatOpenat2 {
+		fp, err := platformResolveBeneath(base, supplement)
+		if err != nil {
+			return nil, err
+		}
+		return backend.OpenFile(fp, flag, perm)
+	}
+
+	fp, rel, err := containmentJoin(base, supplement)
+	if err != nil {
+		return nil, err
+	}
+
+	dirFd, err := unix.Open(base, unix.O_DIRECTORY|unix.O_RDONLY, 0)
+	if err != nil {
+		// base may not exist yet (callers create it via MkdirAll); fall
+		// back to a plain open rather than failing outright.
+		return backend.OpenFile(fp, flag, perm)
+	}
+	defer unix.Close(dirFd)
+
+	// rel is "." when supplement resolves back to base itself (e.g. "",
+	// ".", or "sub/.."); openat2 with RESOLVE_BENEATH rejects an absolute
+	// path outright (EXDEV via path_init()), so it must stay "." rather
+	// than the unchanged absolute path that TrimPrefix(fp, base+"/") would
+	// leave.
+	how := unix.OpenHow{
+		Flags:   uint64(flag) | unix.O_NOFOLLOW,
+		Resolve: unix.RESOLVE_BENEATH | unix.RESOLVE_NO_SYMLINKS,
+	}
+	// As with plain open(2), mode is only meaningful alongside O_CREAT (or
+	// O_TMPFILE); openat2(2) is stricter than open(2) here and rejects a
+	// nonzero Mode outright with EINVAL if neither is set.
+	if flag&os.O_CREATE != 0 || flag&unix.O_TMPFILE == unix.O_TMPFILE {
+		how.Mode = uint64(perm)
+	}
+	fd, err := unix.Openat2(dirFd, rel, &how)
+	if err != nil {
+		// RESOLVE_BENEATH and RESOLVE_NO_SYMLINKS violations surface as
+		// EXDEV and ELOOP respectively; see openat2(2).
+		if err == unix.EXDEV || err == unix.ELOOP {
+			return nil, ErrPathEscapes
+		}
+		return nil, &fs.PathError{Op: "open", Path: fp, Err: err}
+	}
+	return os.NewFile(uintptr(fd), fp), nil
+}
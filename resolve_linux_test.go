@@ -0,0 +1,114 @@
+//go:build linux
+
+package goappfsx
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestOpenUnderOpenat2RejectsSymlinks documents the stricter-than-containment
+// behavior called out on OpenatOpenat2: RESOLVE_NO_SYMLINKS rejects *any*
+// symlink component beneath base, even one that itself resolves to a target
+// safely inside base, unlike the plain containment check OpenatOpenat falls
+// back to.
+func TestOpenUnderOpenat2RejectsSymlinks(t *testing.T) {
+	if probeOpenatMode() != OpenatOpenat2 {
+		t.Skip("openat2(2) not supported by this kernel")
+	}
+
+	base := t.TempDir()
+	target := filepath.Join(base, "real.txt")
+	if err := os.WriteFile(target, []byte("payload"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	link := filepath.Join(base, "link.txt")
+	if err := os.Symlink(target, link); err != nil {
+		t.Fatalf("Symlink: %v", err)
+	}
+
+	defer SetOpenatMode(OpenatAuto)
+
+	SetOpenatMode(OpenatOpenat2)
+	if _, err := openUnder(OSBackend{}, base, "link.txt", os.O_RDONLY, 0); err == nil {
+		t.Fatal("openUnder with OpenatOpenat2: got nil error for a symlink under base, want ErrPathEscapes")
+	} else if err != ErrPathEscapes {
+		t.Fatalf("openUnder with OpenatOpenat2: got err %v, want ErrPathEscapes", err)
+	}
+
+	SetOpenatMode(OpenatOpenat)
+	f, err := openUnder(OSBackend{}, base, "link.txt", os.O_RDONLY, 0)
+	if err != nil {
+		t.Fatalf("openUnder with OpenatOpenat: got err %v, want the symlink to be followed", err)
+	}
+	f.Close()
+}
+
+func TestOpenUnderOpenat2RejectsEscapeOnRealFS(t *testing.T) {
+	if probeOpenatMode() != OpenatOpenat2 {
+		t.Skip("openat2(2) not supported by this kernel")
+	}
+
+	base := t.TempDir()
+	if err := os.WriteFile(filepath.Join(filepath.Dir(base), "outside.txt"), []byte("secret"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	defer SetOpenatMode(OpenatAuto)
+	SetOpenatMode(OpenatOpenat2)
+
+	if _, err := openUnder(OSBackend{}, base, "../outside.txt", os.O_RDONLY, 0); err != ErrPathEscapes {
+		t.Fatalf("openUnder: got err %v, want ErrPathEscapes", err)
+	}
+}
+
+// TestOpenUnderOpenat2NonzeroPermWithoutCreate guards against passing a
+// nonzero perm straight through to OpenHow.Mode regardless of flag: unlike
+// plain open(2), openat2(2) rejects that with EINVAL unless O_CREAT (or
+// O_TMPFILE) is set.
+func TestOpenUnderOpenat2NonzeroPermWithoutCreate(t *testing.T) {
+	if probeOpenatMode() != OpenatOpenat2 {
+		t.Skip("openat2(2) not supported by this kernel")
+	}
+
+	base := t.TempDir()
+	if err := os.WriteFile(filepath.Join(base, "f.txt"), []byte("payload"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	defer SetOpenatMode(OpenatAuto)
+	SetOpenatMode(OpenatOpenat2)
+
+	f, err := openUnder(OSBackend{}, base, "f.txt", os.O_RDONLY, 0o644)
+	if err != nil {
+		t.Fatalf("openUnder with a nonzero perm and no O_CREATE: %v", err)
+	}
+	f.Close()
+}
+
+// TestOpenUnderOpenat2SupplementResolvesToBase guards against rel being
+// derived from fp via strings.TrimPrefix(fp, base+"/"), which silently
+// fails to strip anything when supplement resolves back to base itself
+// (""  , ".", "sub/.."): fp then equals base verbatim, so the untouched
+// absolute path gets passed to Openat2 with RESOLVE_BENEATH set, which
+// openat2(2) rejects outright with EXDEV regardless of dirFd - wrongly
+// surfacing ErrPathEscapes for these non-escaping, publicly reachable
+// inputs (e.g. ReadFileExeDir("") or Store.NewWriter(".")).
+func TestOpenUnderOpenat2SupplementResolvesToBase(t *testing.T) {
+	if probeOpenatMode() != OpenatOpenat2 {
+		t.Skip("openat2(2) not supported by this kernel")
+	}
+
+	base := t.TempDir()
+	defer SetOpenatMode(OpenatAuto)
+	SetOpenatMode(OpenatOpenat2)
+
+	for _, supplement := range []string{"", ".", "sub/.."} {
+		f, err := openUnder(OSBackend{}, base, supplement, os.O_RDONLY, 0)
+		if err != nil {
+			t.Fatalf("openUnder(%q): got err %v, want base itself to open cleanly", supplement, err)
+		}
+		f.Close()
+	}
+}
@@ -0,0 +1,30 @@
+//go:build !linux
+
+package goappfsx
+
+import "os"
+
+// probeOpenatMode always reports OpenatOpenat: openat2(2) is a Linux
+// syscall with no equivalent on this platform.
+func probeOpenatMode() OpenatMode {
+	return OpenatOpenat
+}
+
+// platformResolveBeneath verifies that supplement resolves to a path
+// beneath base using an after-the-fact `path/filepath.Rel` containment
+// check, since openat2's RESOLVE_BENEATH has no portable equivalent here.
+func platformResolveBeneath(base, supplement string) (string, error) {
+	joined, _, err := containmentJoin(base, supplement)
+	return joined, err
+}
+
+// platformOpenBeneath resolves supplement with platformResolveBeneath, then
+// opens it normally; there is no portable equivalent of openat2(2) to
+// collapse the two into one atomic step on this platform.
+func platformOpenBeneath(backend Backend, base, supplement string, flag int, perm os.FileMode) (File, error) {
+	fp, err := platformResolveBeneath(base, supplement)
+	if err != nil {
+		return nil, err
+	}
+	return backend.OpenFile(fp, flag, perm)
+}
@@ -0,0 +1,99 @@
+package goappfsx
+
+import (
+	"errors"
+	"io"
+	"os"
+	"testing"
+)
+
+func TestResolveUnderRejectsEscape(t *testing.T) {
+	cases := []string{
+		"../outside",
+		"a/../../outside",
+		"a/b/../../../outside",
+	}
+	for _, supplement := range cases {
+		if _, err := resolveUnder("/base", supplement); !errors.Is(err, ErrPathEscapes) {
+			t.Errorf("resolveUnder(%q): got err %v, want ErrPathEscapes", supplement, err)
+		}
+	}
+}
+
+func TestResolveUnderAllowsContainedPaths(t *testing.T) {
+	got, err := resolveUnder("/base", "a/b.txt")
+	if err != nil {
+		t.Fatalf("resolveUnder: %v", err)
+	}
+	if want := "/base/a/b.txt"; got != want {
+		t.Fatalf("resolveUnder: got %q, want %q", got, want)
+	}
+}
+
+func TestOpenUnderMemBackendRejectsEscape(t *testing.T) {
+	var m MemBackend
+	if err := m.MkdirAll("/base", 0o755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	_, err := openUnder(&m, "/base", "../outside", os.O_RDONLY, 0)
+	if !errors.Is(err, ErrPathEscapes) {
+		t.Fatalf("openUnder: got err %v, want ErrPathEscapes", err)
+	}
+}
+
+func TestOpenUnderMemBackendRoundTrip(t *testing.T) {
+	var m MemBackend
+	if err := m.MkdirAll("/base", 0o755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	w, err := openUnder(&m, "/base", "f.txt", os.O_WRONLY|os.O_CREATE, 0o644)
+	if err != nil {
+		t.Fatalf("openUnder (write): %v", err)
+	}
+	if _, err := w.Write([]byte("payload")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	w.Close()
+
+	r, err := openUnder(&m, "/base", "f.txt", os.O_RDONLY, 0)
+	if err != nil {
+		t.Fatalf("openUnder (read): %v", err)
+	}
+	defer r.Close()
+	got, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	if string(got) != "payload" {
+		t.Fatalf("got %q, want %q", got, "payload")
+	}
+}
+
+func TestContainmentJoinRelWhenSupplementResolvesToBase(t *testing.T) {
+	for _, supplement := range []string{"", ".", "sub/.."} {
+		joined, rel, err := containmentJoin("/base", supplement)
+		if err != nil {
+			t.Fatalf("containmentJoin(%q): %v", supplement, err)
+		}
+		if joined != "/base" {
+			t.Fatalf("containmentJoin(%q): joined = %q, want %q", supplement, joined, "/base")
+		}
+		if rel != "." {
+			t.Fatalf("containmentJoin(%q): rel = %q, want %q", supplement, rel, ".")
+		}
+	}
+}
+
+func TestSetOpenatModeOverridesProbe(t *testing.T) {
+	defer SetOpenatMode(OpenatAuto)
+
+	SetOpenatMode(OpenatOpenat)
+	if got := effectiveOpenatMode(); got != OpenatOpenat {
+		t.Fatalf("effectiveOpenatMode after SetOpenatMode(OpenatOpenat): got %v, want OpenatOpenat", got)
+	}
+
+	SetOpenatMode(OpenatAuto)
+	if got := effectiveOpenatMode(); got != probeOpenatMode() {
+		t.Fatalf("effectiveOpenatMode after SetOpenatMode(OpenatAuto): got %v, want probeOpenatMode() result", got)
+	}
+}
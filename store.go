@@ -0,0 +1,195 @@
+package goappfsx
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Store is a simple key/value application store layered on top of
+// AppDataDir.  Keys are mapped to files under AppDataDir(category) via a
+// KeyTransform, and values are transparently compressed on write and
+// decompressed on read via a Compressor.  Construct one with NewStore.
+type Store struct {
+	fs         *FS
+	category   DataCategory
+	transform  KeyTransform
+	compressor Compressor
+}
+
+// NewStore returns a Store that keeps its values under
+// AppDataDir(category), using the default Backend, NopTransform, and
+// NopCompression.  Use WithBackend, WithTransform, and WithCompressor to
+// customize it.
+func NewStore(category DataCategory) *Store {
+	return &Store{
+		fs:         defaultFS,
+		category:   category,
+		transform:  NopTransform{},
+		compressor: NopCompression{},
+	}
+}
+
+// WithBackend sets the Backend the Store reads and writes through, and
+// returns the Store for chaining.
+func (s *Store) WithBackend(b Backend) *Store {
+	s.fs = WithBackend(b)
+	return s
+}
+
+// WithTransform sets the KeyTransform used to map keys to relative paths,
+// and returns the Store for chaining.
+func (s *Store) WithTransform(t KeyTransform) *Store {
+	s.transform = t
+	return s
+}
+
+// WithCompressor sets the Compressor used on values, and returns the Store
+// for chaining.
+func (s *Store) WithCompressor(c Compressor) *Store {
+	s.compressor = c
+	return s
+}
+
+// Put stores data under key, compressing it according to the Store's
+// Compressor.
+func (s *Store) Put(key string, data []byte) error {
+	w, err := s.NewWriter(key)
+	if err != nil {
+		return err
+	}
+	if _, werr := w.Write(data); werr != nil {
+		w.Close()
+		return werr
+	}
+	return w.Close()
+}
+
+// Get returns the value stored under key, decompressed according to the
+// Store's Compressor.
+func (s *Store) Get(key string) ([]byte, error) {
+	r, err := s.NewReader(key)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+// NewWriter returns a writer for key.  Closing the returned writer flushes
+// the Compressor and the underlying file; callers must Close it to
+// guarantee the value is fully written.
+func (s *Store) NewWriter(key string) (io.WriteCloser, error) {
+	root, err := s.fs.AppDataDir(s.category)
+	if err != nil {
+		return nil, err
+	}
+	rel := s.transform.Transform(key)
+	fp, err := resolveUnder(root, rel)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.fs.backend.MkdirAll(filepath.Dir(fp), 0o755); err != nil {
+		return nil, err
+	}
+	fyle, err := openUnder(s.fs.backend, root, rel, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	cw, err := s.compressor.NewWriter(fyle)
+	if err != nil {
+		fyle.Close()
+		return nil, err
+	}
+	return &storeWriter{cw: cw, fyle: fyle}, nil
+}
+
+// NewReader returns a reader for key.
+func (s *Store) NewReader(key string) (io.ReadCloser, error) {
+	root, err := s.fs.AppDataDir(s.category)
+	if err != nil {
+		return nil, err
+	}
+	fyle, err := openUnder(s.fs.backend, root, s.transform.Transform(key), os.O_RDONLY, 0)
+	if err != nil {
+		return nil, err
+	}
+	cr, err := s.compressor.NewReader(fyle)
+	if err != nil {
+		fyle.Close()
+		return nil, err
+	}
+	return &storeReader{cr: cr, fyle: fyle}, nil
+}
+
+// Keys returns the keys' relative paths (as produced by the Store's
+// KeyTransform) of every value currently in the Store.  Because a
+// KeyTransform need not be invertible, Keys returns paths rather than the
+// original keys passed to Put.
+func (s *Store) Keys() ([]string, error) {
+	root, err := s.fs.AppDataDir(s.category)
+	if err != nil {
+		return nil, err
+	}
+	var keys []string
+	if err := s.walk(root, root, &keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (s *Store) walk(root, dir string, out *[]string) error {
+	entries, err := s.fs.backend.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		fp := filepath.Join(dir, e.Name())
+		if e.IsDir() {
+			if err := s.walk(root, fp, out); err != nil {
+				return err
+			}
+			continue
+		}
+		*out = append(*out, strings.TrimPrefix(fp, root+string(filepath.Separator)))
+	}
+	return nil
+}
+
+// storeWriter closes the Compressor's writer before the underlying file, so
+// that any buffered compressed output is flushed first.
+type storeWriter struct {
+	cw   io.WriteCloser
+	fyle File
+}
+
+func (w *storeWriter) Write(p []byte) (int, error) {
+	return w.cw.Write(p)
+}
+
+func (w *storeWriter) Close() error {
+	err := w.cw.Close()
+	if cerr := w.fyle.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// storeReader closes the Compressor's reader before the underlying file.
+type storeReader struct {
+	cr   io.ReadCloser
+	fyle File
+}
+
+func (r *storeReader) Read(p []byte) (int, error) {
+	return r.cr.Read(p)
+}
+
+func (r *storeReader) Close() error {
+	err := r.cr.Close()
+	if cerr := r.fyle.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
@@ -0,0 +1,66 @@
+package goappfsx
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestStorePutGetRoundTrip(t *testing.T) {
+	compressors := map[string]Compressor{
+		"nop":  NopCompression{},
+		"gzip": GzipCompression{},
+		"zstd": ZstdCompression{},
+	}
+	for name, c := range compressors {
+		t.Run(name, func(t *testing.T) {
+			var m MemBackend
+			s := NewStore(Local).WithBackend(&m).WithCompressor(c)
+
+			if err := s.Put("session/abc", []byte("payload")); err != nil {
+				t.Fatalf("Put: %v", err)
+			}
+			got, err := s.Get("session/abc")
+			if err != nil {
+				t.Fatalf("Get: %v", err)
+			}
+			if string(got) != "payload" {
+				t.Fatalf("got %q, want %q", got, "payload")
+			}
+		})
+	}
+}
+
+func TestStoreKeys(t *testing.T) {
+	var m MemBackend
+	s := NewStore(Local).WithBackend(&m)
+
+	for _, key := range []string{"a", "nested/b", "nested/c"} {
+		if err := s.Put(key, []byte(key)); err != nil {
+			t.Fatalf("Put(%q): %v", key, err)
+		}
+	}
+
+	keys, err := s.Keys()
+	if err != nil {
+		t.Fatalf("Keys: %v", err)
+	}
+	sort.Strings(keys)
+	want := []string{"a", "nested/b", "nested/c"}
+	if len(keys) != len(want) {
+		t.Fatalf("Keys: got %v, want %v", keys, want)
+	}
+	for i := range want {
+		if keys[i] != want[i] {
+			t.Fatalf("Keys: got %v, want %v", keys, want)
+		}
+	}
+}
+
+func TestStoreGetMissingKey(t *testing.T) {
+	var m MemBackend
+	s := NewStore(Local).WithBackend(&m)
+
+	if _, err := s.Get("missing"); err == nil {
+		t.Fatal("Get of a missing key: got nil error, want a not-exist error")
+	}
+}
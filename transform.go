@@ -0,0 +1,19 @@
+package goappfsx
+
+// KeyTransform maps an application-level key (for example "session/abc") to
+// the path, relative to a Store's root directory, where its value is kept.
+// Implementations are free to flatten, hash, or shard keys as needed; Store
+// joins the result to its root and rejects it with ErrPathEscapes if it
+// resolves outside of that root.
+type KeyTransform interface {
+	Transform(key string) string
+}
+
+// NopTransform is a KeyTransform that uses the key verbatim as the relative
+// path.  It is the default used by NewStore.
+type NopTransform struct{}
+
+// Transform returns key unchanged.
+func (NopTransform) Transform(key string) string {
+	return key
+}